@@ -14,6 +14,7 @@ import (
 	"github.com/cyper-security/gateway/internal/auth"
 	"github.com/cyper-security/gateway/internal/brain"
 	"github.com/cyper-security/gateway/internal/rbac"
+	"github.com/cyper-security/gateway/internal/vault"
 	"github.com/gin-gonic/gin"
 	"github.com/jmoiron/sqlx"
 	"github.com/joho/godotenv"
@@ -81,6 +82,16 @@ func main() {
 	authService := auth.NewAuthService(db, redisClient, jwtSecret, centralAuthURL, pulseInterval, logger)
 	auditLogger := audit.NewAuditLogger(db, logger)
 
+	vaultClient, err := vault.NewClient(vault.Config{
+		Bucket:   getEnv("EVIDENCE_VAULT_BUCKET", "cyper-evidence-vault"),
+		Region:   getEnv("EVIDENCE_VAULT_REGION", "us-east-1"),
+		Endpoint: os.Getenv("EVIDENCE_VAULT_ENDPOINT"), // set for MinIO/S3-compatible deployments
+		KMSKeyID: os.Getenv("EVIDENCE_VAULT_KMS_KEY_ID"),
+	}, logger)
+	if err != nil {
+		logger.Fatal("Failed to initialize evidence vault client", zap.Error(err))
+	}
+
 	// Start authorization pulse checker
 	go authService.StartPulseCheck(ctx)
 
@@ -107,8 +118,15 @@ func main() {
 		authHandler := api.NewAuthHandler(authService, auditLogger)
 		reportHandler := api.NewReportHandler(brainClient, logger)
 		orgHandler := api.NewOrganizationHandler(db, logger)
-		scanAuthHandler := api.NewScanAuthorizationHandler(db, logger)
+		scanAuthHandler := api.NewScanAuthorizationHandler(db, vaultClient, auditLogger, logger)
+		go scanAuthHandler.StartExpiryCheck(ctx)
 		emergencyHandler := api.NewEmergencyHandler(db, redisClient, auditLogger, logger)
+		go emergencyHandler.StartDeadManSwitch(ctx)
+
+		// Scanner worker routes, authenticated by a shared worker token rather than
+		// a user session
+		workerToken := os.Getenv("WORKER_SHARED_TOKEN")
+		v1.POST("/scans/:id/heartbeat", api.WorkerAuthMiddleware(workerToken, logger), emergencyHandler.ReceiveHeartbeat)
 
 		// Public routes
 		auth := v1.Group("/auth")
@@ -148,6 +166,33 @@ func main() {
 				reportHandler.GenerateReport,
 			)
 
+			// Scan authorization submission, listing, verification, and the
+			// pre-scan authorization check
+			protected.POST("/scan-authorizations", scanAuthHandler.SubmitAuthorization)
+			protected.GET("/scan-authorizations", scanAuthHandler.ListAuthorizations)
+			protected.POST("/scan-authorizations/:id/verify", scanAuthHandler.VerifyAuthorization)
+			protected.POST("/scan-authorizations/check", scanAuthHandler.CheckTargetAuthorization)
+
+			// Scan authorization co-signatures, trusted signers, documents, schedules,
+			// and approval delegation/tally
+			protected.POST("/scan-authorizations/:id/countersign", scanAuthHandler.CountersignAuthorization)
+			protected.POST("/scan-authorizations/trusted-signers",
+				rbac.RequirePermission(rbac.PermManageOrganization, logger),
+				scanAuthHandler.ManageTrustedSigners,
+			)
+			protected.POST("/scan-authorizations/:id/documents", scanAuthHandler.UploadAuthorizationDocument)
+			protected.GET("/scan-authorizations/:id/documents/:docId", scanAuthHandler.GetAuthorizationDocument)
+			protected.GET("/scan-authorizations/:id/schedule", scanAuthHandler.GetAuthorizationSchedule)
+			protected.POST("/scan-authorizations/:id/delegate", scanAuthHandler.DelegateApproval)
+			protected.GET("/scan-authorizations/:id/approvals", scanAuthHandler.GetApprovals)
+
+			// Emergency stop
+			protected.POST("/emergency/stop/scoped",
+				rbac.RequirePermission(rbac.PermManageOrganization, logger),
+				emergencyHandler.ActivateScopedEmergencyStop,
+			)
+			protected.GET("/emergency/history", emergencyHandler.GetEmergencyHistory)
+
 			// TODO: Add monitoring routes
 		}
 	}
@@ -2,11 +2,16 @@ package api
 
 import (
 	"context"
+	"crypto/subtle"
+	"database/sql"
+	"encoding/json"
+	"fmt"
 	"net/http"
 	"time"
 
 	"github.com/cyper-security/gateway/internal/audit"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 	"github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
@@ -14,6 +19,16 @@ import (
 
 const EmergencyStopKey = "emergency:stop:active"
 
+// HeartbeatKeyPrefix namespaces the Redis keys scanner workers renew on every
+// heartbeat; a key's TTL expiring without renewal is a missed heartbeat.
+const HeartbeatKeyPrefix = "scan:heartbeat:"
+
+const (
+	heartbeatTTL                 = 90 * time.Second
+	maxHighSeverityFindingsDelta = 10   // per heartbeat interval
+	maxScanRate                  = 5000 // requests or packets per second
+)
+
 type EmergencyHandler struct {
 	db          *sqlx.DB
 	redis       *redis.Client
@@ -58,30 +73,13 @@ func (h *EmergencyHandler) ActivateEmergencyStop(c *gin.Context) {
 		return
 	}
 
-	// Stop all running scans
-	result, err := h.db.Exec(`
-		UPDATE scan_jobs
-		SET status = 'stopped', 
-		    error_message = 'Emergency stop activated: ' || $1,
-		    completed_at = NOW()
-		WHERE status IN ('pending', 'running')
-	`, req.Reason)
-
+	rowsAffected, err := h.stopScans(ctx, userID, req.Reason, req.Duration, stopScope{})
 	if err != nil {
 		h.logger.Error("Failed to stop scans", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to stop scans"})
 		return
 	}
 
-	rowsAffected, _ := result.RowsAffected()
-
-	// Audit log
-	h.auditLogger.LogSecurityEvent(ctx, userID, "emergency_stop_activated", "", "critical", map[string]interface{}{
-		"reason":           req.Reason,
-		"duration_minutes": req.Duration,
-		"scans_stopped":    rowsAffected,
-	})
-
 	h.logger.Warn("Emergency stop activated",
 		zap.String("user_id", userID),
 		zap.String("reason", req.Reason),
@@ -96,6 +94,169 @@ func (h *EmergencyHandler) ActivateEmergencyStop(c *gin.Context) {
 	})
 }
 
+// stopScope narrows a stop to scans matching an organization, target, and/or
+// authorization - all empty means every pending/running scan (the global stop).
+type stopScope struct {
+	OrganizationID  string
+	Target          string
+	AuthorizationID string
+}
+
+// stopScans transitions matching scan_jobs to 'stopped', records the action in
+// emergency_stop_history, and emits an audit event. This is the single code path
+// shared by the manual global stop, the scoped stop endpoint, and the automatic
+// dead-man-switch triggers, so every stop - however it was decided - leaves the
+// same trail.
+func (h *EmergencyHandler) stopScans(ctx context.Context, actor, reason string, durationMinutes int, scope stopScope) (int64, error) {
+	query := `
+		UPDATE scan_jobs
+		SET status = 'stopped',
+		    error_message = 'Emergency stop activated: ' || $1,
+		    completed_at = NOW()
+		WHERE status IN ('pending', 'running')
+	`
+	args := []interface{}{reason}
+
+	if scope.OrganizationID != "" {
+		args = append(args, scope.OrganizationID)
+		query += fmt.Sprintf(" AND organization_id = $%d", len(args))
+	}
+	if scope.Target != "" {
+		args = append(args, scope.Target)
+		query += fmt.Sprintf(" AND target = $%d", len(args))
+	}
+	if scope.AuthorizationID != "" {
+		args = append(args, scope.AuthorizationID)
+		query += fmt.Sprintf(" AND authorization_id = $%d", len(args))
+	}
+
+	result, err := h.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+
+	_, err = h.db.ExecContext(ctx, `
+		INSERT INTO emergency_stop_history (
+			id, action, actor, reason, scans_stopped, duration_minutes,
+			scope_organization_id, scope_target, scope_authorization_id
+		) VALUES ($1, 'activate', $2, $3, $4, $5, $6, $7, $8)
+	`, uuid.New(), actor, reason, rowsAffected, durationMinutes,
+		nullIfEmpty(scope.OrganizationID), nullIfEmpty(scope.Target), nullIfEmpty(scope.AuthorizationID))
+	if err != nil {
+		h.logger.Error("Failed to record emergency stop history", zap.Error(err))
+	}
+
+	h.auditLogger.LogSecurityEvent(ctx, actor, "emergency_stop_activated", scope.AuthorizationID, "critical", map[string]interface{}{
+		"reason":           reason,
+		"duration_minutes": durationMinutes,
+		"scans_stopped":    rowsAffected,
+		"organization_id":  scope.OrganizationID,
+		"target":           scope.Target,
+	})
+
+	return rowsAffected, nil
+}
+
+func nullIfEmpty(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+// ActivateScopedEmergencyStop handles POST /api/v1/emergency/stop/scoped and stops
+// only scans matching the caller's organization, and optionally a target and/or
+// authorization_id within it - the blanket stop above is too blunt once a gateway is
+// serving more than one tenant. The organization is always the caller's own, taken
+// from session context rather than the request body, so a scoped stop can never
+// reach into another tenant; the route also requires PermManageOrganization.
+func (h *EmergencyHandler) ActivateScopedEmergencyStop(c *gin.Context) {
+	userID := c.GetString("user_id")
+	orgID := c.GetString("organization_id")
+	if orgID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Organization context required"})
+		return
+	}
+
+	var req struct {
+		Reason          string `json:"reason" binding:"required"`
+		Target          string `json:"target"`
+		AuthorizationID string `json:"authorization_id"`
+		Duration        int    `json:"duration_minutes"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	scope := stopScope{
+		OrganizationID:  orgID,
+		Target:          req.Target,
+		AuthorizationID: req.AuthorizationID,
+	}
+
+	if req.Duration == 0 {
+		req.Duration = 60
+	}
+
+	ctx := context.Background()
+	rowsAffected, err := h.stopScans(ctx, userID, req.Reason, req.Duration, scope)
+	if err != nil {
+		h.logger.Error("Failed to stop scoped scans", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to stop scans"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":       "Scoped emergency stop activated",
+		"scans_stopped": rowsAffected,
+	})
+}
+
+// GetEmergencyHistory handles GET /api/v1/emergency/history and returns the
+// persisted log of activations and deactivations for audit review, scoped to the
+// caller's own organization (plus unscoped/global events, which affected every
+// tenant and so aren't another org's private data).
+func (h *EmergencyHandler) GetEmergencyHistory(c *gin.Context) {
+	orgID := c.GetString("organization_id")
+	if orgID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Organization context required"})
+		return
+	}
+
+	var history []EmergencyStopHistoryEntry
+	err := h.db.Select(&history, `
+		SELECT * FROM emergency_stop_history
+		WHERE scope_organization_id = $1 OR scope_organization_id IS NULL
+		ORDER BY created_at DESC LIMIT 200
+	`, orgID)
+	if err != nil {
+		h.logger.Error("Failed to load emergency stop history", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load emergency stop history"})
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
+}
+
+// EmergencyStopHistoryEntry is a row in emergency_stop_history: one activation or
+// deactivation, who/what triggered it, why, and its blast radius.
+type EmergencyStopHistoryEntry struct {
+	ID                  string    `json:"id" db:"id"`
+	Action              string    `json:"action" db:"action"` // "activate" or "deactivate"
+	Actor               string    `json:"actor" db:"actor"`   // user ID, or "system" for automatic triggers
+	Reason              string    `json:"reason" db:"reason"`
+	ScansStopped        int64     `json:"scans_stopped" db:"scans_stopped"`
+	DurationMinutes     int       `json:"duration_minutes" db:"duration_minutes"`
+	ScopeOrganizationID *string   `json:"scope_organization_id" db:"scope_organization_id"`
+	ScopeTarget         *string   `json:"scope_target" db:"scope_target"`
+	ScopeAuthorizationID *string  `json:"scope_authorization_id" db:"scope_authorization_id"`
+	CreatedAt           time.Time `json:"created_at" db:"created_at"`
+}
+
 // DeactivateEmergencyStop handles POST /api/v1/emergency/resume
 func (h *EmergencyHandler) DeactivateEmergencyStop(c *gin.Context) {
 	userID := c.GetString("user_id")
@@ -109,6 +270,14 @@ func (h *EmergencyHandler) DeactivateEmergencyStop(c *gin.Context) {
 		return
 	}
 
+	_, err = h.db.ExecContext(ctx, `
+		INSERT INTO emergency_stop_history (id, action, actor, reason, scans_stopped, duration_minutes)
+		VALUES ($1, 'deactivate', $2, '', 0, 0)
+	`, uuid.New(), userID)
+	if err != nil {
+		h.logger.Error("Failed to record emergency stop history", zap.Error(err))
+	}
+
 	// Audit log
 	h.auditLogger.LogSecurityEvent(ctx, userID, "emergency_stop_deactivated", "", "high", map[string]interface{}{
 		"resumed_by": userID,
@@ -181,3 +350,185 @@ func (h *EmergencyHandler) CheckEmergencyStop() gin.HandlerFunc {
 		c.Abort()
 	}
 }
+
+// heartbeatPayload is what a scanner worker POSTs on every heartbeat; ReceiveHeartbeat
+// evaluates it for anomalies and stores it in Redis under the scan's heartbeat key. It intentionally
+// carries no organization/target/authorization fields - those are resolved from
+// scan_jobs server-side (see lookupScanJob) so a worker can't spoof the scope an
+// anomaly auto-stop acts on.
+type heartbeatPayload struct {
+	ScanID               string  `json:"scan_id"`
+	Rate                 float64 `json:"rate"` // requests or packets per second
+	HighSeverityFindings int     `json:"high_severity_findings_delta"`
+}
+
+// scanJobRef is the subset of scan_jobs needed to resolve a heartbeat's true scope.
+type scanJobRef struct {
+	OrganizationID  string `db:"organization_id"`
+	Target          string `db:"target"`
+	TargetType      string `db:"target_type"`
+	AuthorizationID string `db:"authorization_id"`
+}
+
+// lookupScanJob resolves scanID's organization, target and authorization from
+// scan_jobs - the same source of truth checkMissedHeartbeats uses - so neither the
+// heartbeat body nor the caller can choose what an anomaly auto-stop scopes to.
+func (h *EmergencyHandler) lookupScanJob(ctx context.Context, scanID string) (*scanJobRef, error) {
+	var job scanJobRef
+	err := h.db.GetContext(ctx, &job, `
+		SELECT organization_id, target, target_type, authorization_id FROM scan_jobs WHERE id = $1
+	`, scanID)
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// ReceiveHeartbeat handles POST /api/v1/scans/:id/heartbeat. Every scanner worker
+// must call this periodically; StartDeadManSwitch treats a missing key as a missed
+// heartbeat and auto-stops the scan. The heartbeat also carries enough state
+// (rate, finding deltas) to catch anomalous behavior in real time rather than
+// waiting for the TTL to expire.
+func (h *EmergencyHandler) ReceiveHeartbeat(c *gin.Context) {
+	scanID := c.Param("id")
+
+	var payload heartbeatPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	payload.ScanID = scanID
+
+	ctx := c.Request.Context()
+
+	job, err := h.lookupScanJob(ctx, scanID)
+	if err == sql.ErrNoRows {
+		c.JSON(http.StatusNotFound, gin.H{"error": "unknown scan_id"})
+		return
+	}
+	if err != nil {
+		h.logger.Error("Failed to resolve scan job for heartbeat", zap.Error(err), zap.String("scan_id", scanID))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to record heartbeat"})
+		return
+	}
+
+	encoded, err := json.Marshal(payload)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to encode heartbeat"})
+		return
+	}
+	if err := h.redis.Set(ctx, HeartbeatKeyPrefix+scanID, encoded, heartbeatTTL).Err(); err != nil {
+		h.logger.Error("Failed to record heartbeat", zap.Error(err), zap.String("scan_id", scanID))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to record heartbeat"})
+		return
+	}
+
+	if reason := h.detectAnomaly(payload, job); reason != "" {
+		scope := stopScope{OrganizationID: job.OrganizationID, Target: job.Target, AuthorizationID: job.AuthorizationID}
+		if _, err := h.stopScans(ctx, "system", reason, 0, scope); err != nil {
+			h.logger.Error("Failed to auto-stop on anomaly", zap.Error(err), zap.String("scan_id", scanID))
+		} else {
+			h.logger.Warn("Auto emergency stop triggered by anomalous heartbeat",
+				zap.String("scan_id", scanID), zap.String("reason", reason))
+		}
+		c.JSON(http.StatusOK, gin.H{"acknowledged": true, "auto_stopped": true, "reason": reason})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"acknowledged": true, "auto_stopped": false})
+}
+
+// detectAnomaly returns a non-empty reason if a heartbeat shows behavior that should
+// trigger an automatic emergency stop: an excessive rate, a burst of high-severity
+// findings, or the scan's target drifting outside what it was authorized for. job is
+// resolved server-side from scan_jobs, never from the heartbeat body.
+func (h *EmergencyHandler) detectAnomaly(payload heartbeatPayload, job *scanJobRef) string {
+	if payload.Rate > maxScanRate {
+		return "auto:anomaly"
+	}
+	if payload.HighSeverityFindings > maxHighSeverityFindingsDelta {
+		return "auto:anomaly"
+	}
+
+	if job.AuthorizationID != "" {
+		scanAuthHandler := &ScanAuthorizationHandler{db: h.db, logger: h.logger}
+		match, err := scanAuthHandler.matchAuthorization(job.OrganizationID, job.TargetType, job.Target)
+		if err != nil {
+			h.logger.Error("Failed to re-check target authorization for drift", zap.Error(err))
+			return ""
+		}
+		if match == nil || match.AuthorizationID != job.AuthorizationID {
+			return "auto:anomaly"
+		}
+	}
+
+	return ""
+}
+
+// StartDeadManSwitch runs until ctx is cancelled, periodically checking that every
+// scan_jobs row in 'running' status still has a live scan:heartbeat:<id> key in
+// Redis; a missing key means the worker stopped reporting without finishing, so the
+// scan is auto-stopped the same way a manual emergency stop would stop it.
+func (h *EmergencyHandler) StartDeadManSwitch(ctx context.Context) {
+	ticker := time.NewTicker(30 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			h.checkMissedHeartbeats(ctx)
+		}
+	}
+}
+
+func (h *EmergencyHandler) checkMissedHeartbeats(ctx context.Context) {
+	var runningScans []struct {
+		ID              string `db:"id"`
+		OrganizationID  string `db:"organization_id"`
+		Target          string `db:"target"`
+		AuthorizationID string `db:"authorization_id"`
+	}
+	err := h.db.SelectContext(ctx, &runningScans, `
+		SELECT id, organization_id, target, authorization_id FROM scan_jobs WHERE status = 'running'
+	`)
+	if err != nil {
+		h.logger.Error("Failed to list running scans for dead-man switch", zap.Error(err))
+		return
+	}
+
+	for _, scan := range runningScans {
+		exists, err := h.redis.Exists(ctx, HeartbeatKeyPrefix+scan.ID).Result()
+		if err != nil {
+			h.logger.Error("Failed to check heartbeat key", zap.Error(err), zap.String("scan_id", scan.ID))
+			continue
+		}
+		if exists > 0 {
+			continue
+		}
+
+		scope := stopScope{OrganizationID: scan.OrganizationID, Target: scan.Target, AuthorizationID: scan.AuthorizationID}
+		if _, err := h.stopScans(ctx, "system", "auto:missed_heartbeat", 0, scope); err != nil {
+			h.logger.Error("Failed to auto-stop missed-heartbeat scan", zap.Error(err), zap.String("scan_id", scan.ID))
+		} else {
+			h.logger.Warn("Auto emergency stop triggered by missed heartbeat", zap.String("scan_id", scan.ID))
+		}
+	}
+}
+
+// WorkerAuthMiddleware checks the X-Worker-Token header against the shared secret
+// every scanner worker is provisioned with, since heartbeat calls come from workers
+// rather than an authenticated user session. The comparison runs in constant time so
+// a network attacker can't use response timing to recover this long-lived secret.
+func WorkerAuthMiddleware(sharedToken string, logger *zap.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := c.GetHeader("X-Worker-Token")
+		if sharedToken == "" || subtle.ConstantTimeCompare([]byte(header), []byte(sharedToken)) != 1 {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid worker token"})
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
@@ -1,39 +1,77 @@
 package api
 
 import (
+	"bytes"
+	"context"
 	"crypto/sha256"
+	"crypto/x509"
 	"database/sql"
 	"encoding/hex"
+	"encoding/pem"
 	"fmt"
+	"io"
+	"net"
 	"net/http"
+	"strings"
 	"time"
 
+	"github.com/cyper-security/gateway/internal/audit"
+	"github.com/cyper-security/gateway/internal/vault"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/robfig/cron/v3"
+	"go.mozilla.org/pkcs7"
 	"go.uber.org/zap"
+	"golang.org/x/crypto/openpgp"
 )
 
+// cronParser parses standard 5-field cron expressions ("minute hour dom month dow"),
+// matching the format customers already use elsewhere (e.g. job schedulers), rather
+// than robfig/cron's non-standard default that adds a seconds field.
+var cronParser = cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
+
 type ScanAuthorizationHandler struct {
-	db     *sqlx.DB
-	logger *zap.Logger
+	db          *sqlx.DB
+	vault       *vault.Client
+	auditLogger *audit.AuditLogger
+	logger      *zap.Logger
 }
 
-func NewScanAuthorizationHandler(db *sqlx.DB, logger *zap.Logger) *ScanAuthorizationHandler {
+func NewScanAuthorizationHandler(db *sqlx.DB, vaultClient *vault.Client, auditLogger *audit.AuditLogger, logger *zap.Logger) *ScanAuthorizationHandler {
 	return &ScanAuthorizationHandler{
-		db:     db,
-		logger: logger,
+		db:          db,
+		vault:       vaultClient,
+		auditLogger: auditLogger,
+		logger:      logger,
 	}
 }
 
 type SubmitAuthorizationRequest struct {
-	TargetType          string    `json:"target_type" binding:"required"` // ip, domain, cidr, etc.
-	TargetValue         string    `json:"target_value" binding:"required"`
-	AuthorizedBy        string    `json:"authorized_by" binding:"required"`
-	AuthorizationDocURL string    `json:"authorization_document_url" binding:"required"`
-	ValidFrom           time.Time `json:"valid_from" binding:"required"`
-	ValidUntil          time.Time `json:"valid_until" binding:"required"`
-	ScopeLimitations    string    `json:"scope_limitations"` // JSON string
+	TargetType   string `json:"target_type" binding:"required"` // ip, domain, cidr, etc.
+	TargetValue  string `json:"target_value" binding:"required"`
+	AuthorizedBy string `json:"authorized_by" binding:"required"`
+	// AuthorizationDocURL, if set and AuthorizationDocument is empty, is fetched
+	// once and mirrored into the evidence vault. Prefer AuthorizationDocument (a
+	// direct upload of the document bytes) when the caller already has them.
+	AuthorizationDocURL string `json:"authorization_document_url"`
+	// AuthorizationDocument holds the raw bytes of the signed document itself, so the
+	// hash stored alongside the authorization binds to its contents rather than a URL
+	// that could be swapped out from under us after submission.
+	AuthorizationDocument []byte    `json:"authorization_document"`
+	DetachedSignature     []byte    `json:"detached_signature" binding:"required"`
+	SignatureFormat       string    `json:"signature_format" binding:"required"` // "pgp" or "pkcs7"
+	ValidFrom             time.Time `json:"valid_from" binding:"required"`
+	ValidUntil            time.Time `json:"valid_until" binding:"required"`
+	ScopeLimitations      string    `json:"scope_limitations"` // JSON string
+	// CronExpression, WindowDuration and Timezone together describe a recurring
+	// window (e.g. "Sundays 02:00-06:00") instead of one continuous valid_from/
+	// valid_until range. Leave CronExpression empty for a plain continuous window.
+	CronExpression  string      `json:"cron_expression"`
+	WindowDuration  string      `json:"window_duration"` // Go duration string, e.g. "4h"
+	Timezone        string      `json:"timezone"`        // IANA name, defaults to UTC
+	BlackoutWindows []TimeRange `json:"blackout_windows"`
 }
 
 type Authorization struct {
@@ -42,15 +80,257 @@ type Authorization struct {
 	TargetType               string     `json:"target_type" db:"target_type"`
 	TargetValue              string     `json:"target_value" db:"target_value"`
 	AuthorizationDocumentURL string     `json:"authorization_document_url" db:"authorization_document_url"`
-	AuthorizationHash        string     `json:"authorization_hash" db:"authorization_hash"`
-	AuthorizedBy             string     `json:"authorized_by" db:"authorized_by"`
-	ValidFrom                time.Time  `json:"valid_from" db:"valid_from"`
-	ValidUntil               time.Time  `json:"valid_until" db:"valid_until"`
-	VerificationStatus       string     `json:"verification_status" db:"verification_status"`
-	VerifiedByUserID         *string    `json:"verified_by_user_id" db:"verified_by_user_id"`
-	VerifiedAt               *time.Time `json:"verified_at" db:"verified_at"`
-	RejectionReason          *string    `json:"rejection_reason" db:"rejection_reason"`
-	CreatedAt                time.Time  `json:"created_at" db:"created_at"`
+	AuthorizationDocument    []byte     `json:"-" db:"authorization_document"`
+	// IPRange holds the CIDR (Postgres inet/cidr column) for target_type='cidr'
+	// authorizations, indexed with GiST so containment checks (inet <<= cidr) don't
+	// table-scan. Nil for non-CIDR target types.
+	IPRange            *string    `json:"ip_range,omitempty" db:"ip_range"`
+	AuthorizationHash  string     `json:"authorization_hash" db:"authorization_hash"`
+	AuthorizedBy       string     `json:"authorized_by" db:"authorized_by"`
+	ValidFrom          time.Time  `json:"valid_from" db:"valid_from"`
+	ValidUntil         time.Time  `json:"valid_until" db:"valid_until"`
+	VerificationStatus string     `json:"verification_status" db:"verification_status"`
+	VerifiedByUserID   *string    `json:"verified_by_user_id" db:"verified_by_user_id"`
+	VerifiedAt         *time.Time `json:"verified_at" db:"verified_at"`
+	RejectionReason    *string    `json:"rejection_reason" db:"rejection_reason"`
+	// CronExpression/WindowDuration/Timezone narrow the continuous ValidFrom/
+	// ValidUntil range down to a recurring schedule; CronExpression is empty when
+	// the authorization is just the plain continuous window.
+	CronExpression string    `json:"cron_expression" db:"cron_expression"`
+	WindowDuration string    `json:"window_duration" db:"window_duration"`
+	Timezone       string    `json:"timezone" db:"timezone"`
+	CreatedAt      time.Time `json:"created_at" db:"created_at"`
+}
+
+// TimeRange is a row in authorization_blackout_windows: a span during which an
+// otherwise-valid authorization must NOT be treated as authorizing a scan (e.g. a
+// quarter-end change freeze).
+type TimeRange struct {
+	ID              string    `json:"id" db:"id"`
+	AuthorizationID string    `json:"authorization_id" db:"authorization_id"`
+	StartsAt        time.Time `json:"starts_at" db:"starts_at"`
+	EndsAt          time.Time `json:"ends_at" db:"ends_at"`
+	Label           string    `json:"label" db:"label"`
+}
+
+// AuthorizationSignature is a row in authorization_signatures: one cryptographic
+// signature (the original submission or a later countersignature) bound to the
+// authorization's document bytes.
+type AuthorizationSignature struct {
+	ID                string    `json:"id" db:"id"`
+	AuthorizationID   string    `json:"authorization_id" db:"authorization_id"`
+	SignerFingerprint string    `json:"signer_fingerprint" db:"signer_fingerprint"`
+	SignatureFormat   string    `json:"signature_format" db:"signature_format"`
+	IsCountersignature bool     `json:"is_countersignature" db:"is_countersignature"`
+	TrustedAtSignTime bool      `json:"trusted_at_sign_time" db:"trusted_at_sign_time"`
+	CreatedBy         string    `json:"created_by" db:"created_by"`
+	CreatedAt         time.Time `json:"created_at" db:"created_at"`
+}
+
+// TrustedSigner is a row in the per-organization trust store: a signer fingerprint
+// (PGP key fingerprint or X.509 certificate fingerprint) permitted to sign
+// authorization documents, along with the key/certificate material needed to verify.
+type TrustedSigner struct {
+	ID             string    `json:"id" db:"id"`
+	OrganizationID string    `json:"organization_id" db:"organization_id"`
+	Fingerprint    string    `json:"fingerprint" db:"fingerprint"`
+	Format         string    `json:"format" db:"format"` // "pgp" or "pkcs7"
+	KeyMaterial    string    `json:"-" db:"key_material"` // armored PGP public key or PEM certificate
+	Label          string    `json:"label" db:"label"`
+	CreatedAt      time.Time `json:"created_at" db:"created_at"`
+}
+
+// AuthorizationDocumentRecord is a row in authorization_documents: one object stored
+// in the evidence vault and bound to an authorization by its content hash.
+type AuthorizationDocumentRecord struct {
+	ID              string    `json:"id" db:"id"`
+	AuthorizationID string    `json:"authorization_id" db:"authorization_id"`
+	Bucket          string    `json:"bucket" db:"bucket"`
+	ObjectKey       string    `json:"object_key" db:"object_key"`
+	SizeBytes       int64     `json:"size_bytes" db:"size_bytes"`
+	ContentType     string    `json:"content_type" db:"content_type"`
+	SHA256          string    `json:"sha256" db:"sha256"`
+	CreatedAt       time.Time `json:"created_at" db:"created_at"`
+}
+
+// storeEvidence uploads body into the vault under the authorization's immutable key
+// and records it in authorization_documents.
+func (h *ScanAuthorizationHandler) storeEvidence(c *gin.Context, orgID, authID string, body []byte, contentType string) (*AuthorizationDocumentRecord, error) {
+	hash := sha256.Sum256(body)
+	sha256Hex := hex.EncodeToString(hash[:])
+	key := vault.Key(orgID, authID, sha256Hex)
+
+	if err := h.vault.Put(c.Request.Context(), key, body, contentType); err != nil {
+		return nil, err
+	}
+
+	record := &AuthorizationDocumentRecord{
+		ID:              uuid.New().String(),
+		AuthorizationID: authID,
+		Bucket:          h.vault.Bucket(),
+		ObjectKey:       key,
+		SizeBytes:       int64(len(body)),
+		ContentType:     contentType,
+		SHA256:          sha256Hex,
+	}
+
+	_, err := h.db.Exec(`
+		INSERT INTO authorization_documents (
+			id, authorization_id, bucket, object_key, size_bytes, content_type, sha256
+		) VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`, record.ID, record.AuthorizationID, record.Bucket, record.ObjectKey,
+		record.SizeBytes, record.ContentType, record.SHA256)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record evidence document: %w", err)
+	}
+
+	return record, nil
+}
+
+// UploadAuthorizationDocument handles POST /api/v1/scan-authorizations/:id/documents
+// (multipart/form-data, field "file") to attach additional evidence - e.g. a signed
+// scope addendum - to an existing authorization.
+func (h *ScanAuthorizationHandler) UploadAuthorizationDocument(c *gin.Context) {
+	authID := c.Param("id")
+	orgID := c.GetString("organization_id")
+	if orgID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Organization context required"})
+		return
+	}
+
+	var auth Authorization
+	err := h.db.Get(&auth, "SELECT * FROM authorized_targets WHERE id = $1 AND organization_id = $2", authID, orgID)
+	if err == sql.ErrNoRows {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Authorization not found"})
+		return
+	}
+	if err != nil {
+		h.logger.Error("Failed to fetch authorization", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch authorization"})
+		return
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "file is required"})
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "failed to open uploaded file"})
+		return
+	}
+	defer file.Close()
+
+	body, err := io.ReadAll(file)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "failed to read uploaded file"})
+		return
+	}
+
+	record, err := h.storeEvidence(c, orgID, authID, body, fileHeader.Header.Get("Content-Type"))
+	if err != nil {
+		h.logger.Error("Failed to store evidence document", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to store evidence document"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, record)
+}
+
+// GetAuthorizationDocument handles GET /api/v1/scan-authorizations/:id/documents/:docId
+// and returns a pre-signed, time-limited download URL for the stored object.
+func (h *ScanAuthorizationHandler) GetAuthorizationDocument(c *gin.Context) {
+	authID := c.Param("id")
+	docID := c.Param("docId")
+	orgID := c.GetString("organization_id")
+	if orgID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Organization context required"})
+		return
+	}
+
+	var doc AuthorizationDocumentRecord
+	err := h.db.Get(&doc, `
+		SELECT d.* FROM authorization_documents d
+		JOIN authorized_targets t ON t.id = d.authorization_id
+		WHERE d.id = $1 AND d.authorization_id = $2 AND t.organization_id = $3
+	`, docID, authID, orgID)
+	if err == sql.ErrNoRows {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Document not found"})
+		return
+	}
+	if err != nil {
+		h.logger.Error("Failed to fetch evidence document", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch evidence document"})
+		return
+	}
+
+	url, err := h.vault.PresignGet(doc.ObjectKey, 15*time.Minute)
+	if err != nil {
+		h.logger.Error("Failed to presign evidence document", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate download URL"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"download_url": url,
+		"expires_in":   "15m",
+		"sha256":       doc.SHA256,
+	})
+}
+
+// verifyDetachedSignature checks that signature is a valid detached signature over
+// document produced by one of the organization's trusted signers, returning the
+// fingerprint of the signer that produced it.
+func (h *ScanAuthorizationHandler) verifyDetachedSignature(orgID, format string, document, signature []byte) (string, error) {
+	var signers []TrustedSigner
+	err := h.db.Select(&signers, `
+		SELECT * FROM trusted_signers WHERE organization_id = $1 AND format = $2
+	`, orgID, format)
+	if err != nil {
+		return "", fmt.Errorf("failed to load trusted signers: %w", err)
+	}
+
+	switch format {
+	case "pgp":
+		for _, signer := range signers {
+			keyring, err := openpgp.ReadArmoredKeyRing(strings.NewReader(signer.KeyMaterial))
+			if err != nil {
+				continue
+			}
+			signer2, err := openpgp.CheckDetachedSignature(keyring, bytes.NewReader(document), bytes.NewReader(signature))
+			if err != nil || signer2 == nil {
+				continue
+			}
+			return signer.Fingerprint, nil
+		}
+	case "pkcs7":
+		p7, err := pkcs7.Parse(signature)
+		if err != nil {
+			return "", fmt.Errorf("invalid pkcs7 signature: %w", err)
+		}
+		p7.Content = document
+		for _, signer := range signers {
+			block, _ := pem.Decode([]byte(signer.KeyMaterial))
+			if block == nil {
+				continue
+			}
+			cert, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				continue
+			}
+			pool := x509.NewCertPool()
+			pool.AddCert(cert)
+			if err := p7.VerifyWithChain(pool); err == nil {
+				return signer.Fingerprint, nil
+			}
+		}
+	default:
+		return "", fmt.Errorf("unsupported signature_format %q", format)
+	}
+
+	return "", fmt.Errorf("signature does not chain to a trusted signer")
 }
 
 // SubmitAuthorization handles POST /api/v1/scan-authorizations
@@ -73,22 +353,80 @@ func (h *ScanAuthorizationHandler) SubmitAuthorization(c *gin.Context) {
 		return
 	}
 
-	// Compute hash of authorization document URL (as proof it was submitted)
-	hash := sha256.Sum256([]byte(req.AuthorizationDocURL))
+	var ipRange *string
+	if req.TargetType == "cidr" {
+		if _, _, err := net.ParseCIDR(req.TargetValue); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "target_value must be a valid CIDR for target_type 'cidr'"})
+			return
+		}
+		ipRange = &req.TargetValue
+	}
+
+	if req.Timezone == "" {
+		req.Timezone = "UTC"
+	}
+	if _, err := time.LoadLocation(req.Timezone); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid timezone %q: %v", req.Timezone, err)})
+		return
+	}
+	if req.CronExpression != "" {
+		if _, err := cronParser.Parse(req.CronExpression); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid cron_expression: %v", err)})
+			return
+		}
+		if _, err := time.ParseDuration(req.WindowDuration); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid window_duration: %v", err)})
+			return
+		}
+	}
+
+	document := req.AuthorizationDocument
+	if len(document) == 0 {
+		if req.AuthorizationDocURL == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "authorization_document or authorization_document_url is required"})
+			return
+		}
+		fetched, err := fetchAuthorizationDocument(req.AuthorizationDocURL)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("failed to fetch authorization_document_url: %v", err)})
+			return
+		}
+		document = fetched
+	}
+
+	// Hash the signed document contents, not the URL string - a URL proves nothing
+	// about what the document actually says.
+	hash := sha256.Sum256(document)
 	authHash := hex.EncodeToString(hash[:])
 
+	fingerprint, err := h.verifyDetachedSignature(orgID, req.SignatureFormat, document, req.DetachedSignature)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("signature verification failed: %v", err)})
+		return
+	}
+
 	authID := uuid.New()
 
+	tx, err := h.db.Beginx()
+	if err != nil {
+		h.logger.Error("Failed to begin transaction", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to submit authorization"})
+		return
+	}
+	defer tx.Rollback()
+
 	// Insert authorization
-	_, err := h.db.Exec(`
+	_, err = tx.Exec(`
 		INSERT INTO authorized_targets (
-			id, organization_id, target_type, target_value,
-			authorization_document_url, authorization_hash,
-			authorized_by, valid_from, valid_until, verification_status
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, 'pending')
-	`, authID, orgID, req.TargetType, req.TargetValue,
-		req.AuthorizationDocURL, authHash,
-		req.AuthorizedBy, req.ValidFrom, req.ValidUntil)
+			id, organization_id, target_type, target_value, ip_range,
+			authorization_document_url, authorization_document, authorization_hash,
+			authorized_by, valid_from, valid_until, verification_status,
+			cron_expression, window_duration, timezone
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, 'pending', $12, $13, $14)
+	`, authID, orgID, req.TargetType, req.TargetValue, ipRange,
+		req.AuthorizationDocURL, document, authHash,
+		req.AuthorizedBy, req.ValidFrom, req.ValidUntil,
+		req.CronExpression, req.WindowDuration, req.Timezone)
 
 	if err != nil {
 		h.logger.Error("Failed to submit authorization", zap.Error(err))
@@ -96,13 +434,234 @@ func (h *ScanAuthorizationHandler) SubmitAuthorization(c *gin.Context) {
 		return
 	}
 
+	_, err = tx.Exec(`
+		INSERT INTO authorization_signatures (
+			id, authorization_id, signer_fingerprint, signature_format,
+			is_countersignature, trusted_at_sign_time, created_by
+		) VALUES ($1, $2, $3, $4, false, true, $5)
+	`, uuid.New(), authID, fingerprint, req.SignatureFormat, req.AuthorizedBy)
+
+	if err != nil {
+		h.logger.Error("Failed to record authorization signature", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to submit authorization"})
+		return
+	}
+
+	for _, blackout := range req.BlackoutWindows {
+		_, err = tx.Exec(`
+			INSERT INTO authorization_blackout_windows (id, authorization_id, starts_at, ends_at, label)
+			VALUES ($1, $2, $3, $4, $5)
+		`, uuid.New(), authID, blackout.StartsAt, blackout.EndsAt, blackout.Label)
+		if err != nil {
+			h.logger.Error("Failed to record blackout window", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to submit authorization"})
+			return
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		h.logger.Error("Failed to commit authorization submission", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to submit authorization"})
+		return
+	}
+
+	if _, err := h.storeEvidence(c, orgID, authID.String(), document, "application/octet-stream"); err != nil {
+		// The authorization row is already committed; the vault mirror is best-effort
+		// evidence retention and can be retried via the documents endpoint.
+		h.logger.Error("Failed to mirror authorization document into evidence vault", zap.Error(err))
+	}
+
 	c.JSON(http.StatusCreated, gin.H{
 		"id":                  authID.String(),
 		"verification_status": "pending",
+		"signer_fingerprint":  fingerprint,
 		"message":             "Authorization submitted for review",
 	})
 }
 
+// maxAuthorizationDocumentBytes caps the size of a fetched authorization_document_url
+// response so a malicious or misconfigured endpoint can't exhaust memory with an
+// unbounded (or streamed-forever) body.
+const maxAuthorizationDocumentBytes = 25 * 1024 * 1024 // 25MiB
+
+// fetchAuthorizationDocument retrieves the document bytes from a caller-supplied URL
+// so it can be hashed and mirrored into the evidence vault rather than trusted as-is.
+// The URL is attacker-influenced, so every connection it makes - including ones made
+// following a redirect - is resolved and checked against loopback/link-local/private
+// ranges (which also covers the 169.254.169.254 cloud metadata address) before dialing,
+// and the response body is capped so a huge response can't be used as a DoS.
+func fetchAuthorizationDocument(url string) ([]byte, error) {
+	client := &http.Client{
+		Timeout: 15 * time.Second,
+		Transport: &http.Transport{
+			DialContext: dialPublicOnly,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= 5 {
+				return fmt.Errorf("too many redirects")
+			}
+			return nil
+		},
+	}
+
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxAuthorizationDocumentBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(body) > maxAuthorizationDocumentBytes {
+		return nil, fmt.Errorf("authorization document exceeds %d bytes", maxAuthorizationDocumentBytes)
+	}
+
+	return body, nil
+}
+
+// dialPublicOnly is an http.Transport.DialContext that resolves addr's host and
+// refuses to connect if any resolved address is not publicly routable. Checking the
+// URL's hostname up front isn't enough on its own - a DNS answer or a redirect can
+// still point at a private or metadata address - so this check runs on every dial,
+// which is what actually closes off the SSRF.
+func dialPublicOnly(ctx context.Context, network, addr string) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	ips, err := net.DefaultResolver.LookupIP(ctx, "ip", host)
+	if err != nil {
+		return nil, err
+	}
+	for _, ip := range ips {
+		if !isPubliclyRoutable(ip) {
+			return nil, fmt.Errorf("refusing to fetch authorization document from non-public address %s", ip)
+		}
+	}
+
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+	return dialer.DialContext(ctx, network, net.JoinHostPort(ips[0].String(), port))
+}
+
+// isPubliclyRoutable reports whether ip is safe for a server-side fetch to connect
+// to: not loopback, link-local (which includes the 169.254.169.254 cloud metadata
+// address), unspecified, multicast, or an RFC1918/ULA private range.
+func isPubliclyRoutable(ip net.IP) bool {
+	return !ip.IsLoopback() && !ip.IsLinkLocalUnicast() && !ip.IsLinkLocalMulticast() &&
+		!ip.IsUnspecified() && !ip.IsMulticast() && !ip.IsPrivate()
+}
+
+// CountersignAuthorization handles POST /api/v1/scan-authorizations/:id/countersign
+// It lets a second authorized_by principal add a co-signature over the same document
+// bytes that were submitted with the original request.
+func (h *ScanAuthorizationHandler) CountersignAuthorization(c *gin.Context) {
+	authID := c.Param("id")
+	orgID := c.GetString("organization_id")
+	if orgID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Organization context required"})
+		return
+	}
+
+	var req struct {
+		AuthorizedBy      string `json:"authorized_by" binding:"required"`
+		DetachedSignature []byte `json:"detached_signature" binding:"required"`
+		SignatureFormat   string `json:"signature_format" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var auth Authorization
+	err := h.db.Get(&auth, "SELECT * FROM authorized_targets WHERE id = $1 AND organization_id = $2", authID, orgID)
+	if err == sql.ErrNoRows {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Authorization not found"})
+		return
+	}
+	if err != nil {
+		h.logger.Error("Failed to fetch authorization", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch authorization"})
+		return
+	}
+
+	fingerprint, err := h.verifyDetachedSignature(orgID, req.SignatureFormat, auth.AuthorizationDocument, req.DetachedSignature)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("signature verification failed: %v", err)})
+		return
+	}
+
+	_, err = h.db.Exec(`
+		INSERT INTO authorization_signatures (
+			id, authorization_id, signer_fingerprint, signature_format,
+			is_countersignature, trusted_at_sign_time, created_by
+		) VALUES ($1, $2, $3, $4, true, true, $5)
+	`, uuid.New(), authID, fingerprint, req.SignatureFormat, req.AuthorizedBy)
+
+	if err != nil {
+		h.logger.Error("Failed to record countersignature", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to record countersignature"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"authorization_id":   authID,
+		"signer_fingerprint": fingerprint,
+		"message":            "Countersignature recorded",
+	})
+}
+
+// ManageTrustedSigners handles POST /api/v1/scan-authorizations/trusted-signers
+// Admins use this to add or update the fingerprints/certificates this organization
+// will accept as valid signers for authorization documents.
+func (h *ScanAuthorizationHandler) ManageTrustedSigners(c *gin.Context) {
+	orgID := c.GetString("organization_id")
+	if orgID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Organization context required"})
+		return
+	}
+
+	var req struct {
+		Fingerprint string `json:"fingerprint" binding:"required"`
+		Format      string `json:"format" binding:"required"` // "pgp" or "pkcs7"
+		KeyMaterial string `json:"key_material" binding:"required"`
+		Label       string `json:"label"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if req.Format != "pgp" && req.Format != "pkcs7" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "format must be 'pgp' or 'pkcs7'"})
+		return
+	}
+
+	_, err := h.db.Exec(`
+		INSERT INTO trusted_signers (id, organization_id, fingerprint, format, key_material, label)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (organization_id, fingerprint) DO UPDATE
+		SET format = EXCLUDED.format, key_material = EXCLUDED.key_material, label = EXCLUDED.label
+	`, uuid.New(), orgID, req.Fingerprint, req.Format, req.KeyMaterial, req.Label)
+
+	if err != nil {
+		h.logger.Error("Failed to store trusted signer", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to store trusted signer"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"fingerprint": req.Fingerprint,
+		"message":     "Trusted signer stored",
+	})
+}
+
 // ListAuthorizations handles GET /api/v1/scan-authorizations
 func (h *ScanAuthorizationHandler) ListAuthorizations(c *gin.Context) {
 	orgID := c.GetString("organization_id")
@@ -141,10 +700,18 @@ func (h *ScanAuthorizationHandler) ListAuthorizations(c *gin.Context) {
 func (h *ScanAuthorizationHandler) VerifyAuthorization(c *gin.Context) {
 	authID := c.Param("id")
 	userID := c.GetString("user_id")
+	orgID := c.GetString("organization_id")
+	if orgID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Organization context required"})
+		return
+	}
 
 	var req struct {
-		Action string `json:"action" binding:"required"` // approve or reject
-		Reason string `json:"reason"`                    // Only for reject
+		Action             string    `json:"action" binding:"required"` // approve or reject
+		Reason             string    `json:"reason"`                    // Only for reject
+		TargetClassification string  `json:"target_classification"`     // e.g. "prod", "pci" - selects the policy
+		MFAAssertionID     string    `json:"mfa_assertion_id"`
+		MFAVerifiedAt      time.Time `json:"mfa_verified_at"`
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -157,46 +724,144 @@ func (h *ScanAuthorizationHandler) VerifyAuthorization(c *gin.Context) {
 		return
 	}
 
-	// Check if authorization exists
+	// Check if authorization exists - scoped to the caller's organization, same as
+	// CountersignAuthorization/UploadAuthorizationDocument, so an account in one
+	// tenant can't approve (or count towards quorum on) another tenant's authorization.
 	var auth Authorization
-	err := h.db.Get(&auth, "SELECT * FROM authorized_targets WHERE id = $1", authID)
+	err := h.db.Get(&auth, "SELECT * FROM authorized_targets WHERE id = $1 AND organization_id = $2", authID, orgID)
 	if err == sql.ErrNoRows {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Authorization not found"})
 		return
 	}
-
 	if err != nil {
 		h.logger.Error("Failed to fetch authorization", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch authorization"})
 		return
 	}
 
-	// Update verification status
-	newStatus := "approved"
-	if req.Action == "reject" {
-		newStatus = "rejected"
+	policy, err := h.getApprovalPolicy(auth.OrganizationID, req.TargetClassification)
+	if err != nil {
+		h.logger.Error("Failed to load approval policy", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load approval policy"})
+		return
 	}
 
-	var updateQuery string
-	var args []interface{}
+	approver := userID
+	if delegator, err := h.activeDelegator(authID, userID); err != nil {
+		h.logger.Error("Failed to check approval delegation", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check approval delegation"})
+		return
+	} else if delegator != "" {
+		approver = delegator
+	}
 
-	if newStatus == "rejected" {
-		updateQuery = `
-			UPDATE authorized_targets
-			SET verification_status = $1, verified_by_user_id = $2, verified_at = NOW(), rejection_reason = $3
-			WHERE id = $4
-		`
-		args = []interface{}{newStatus, userID, req.Reason, authID}
-	} else {
-		updateQuery = `
+	if len(policy.RequiredRoles) > 0 {
+		approverRole, err := h.memberRole(auth.OrganizationID, approver)
+		if err != nil {
+			h.logger.Error("Failed to look up approver's organization role", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check approver's role"})
+			return
+		}
+		if !containsRole(policy.RequiredRoles, approverRole) {
+			c.JSON(http.StatusForbidden, gin.H{"error": fmt.Sprintf("this authorization's approval policy requires one of roles %v", []string(policy.RequiredRoles))})
+			return
+		}
+	}
+
+	if policy.RequireStepUpMFA {
+		if req.MFAAssertionID == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "mfa_assertion_id is required for this authorization's approval policy"})
+			return
+		}
+		if req.MFAVerifiedAt.IsZero() || time.Since(req.MFAVerifiedAt) > time.Duration(policy.StepUpMFAWindowMinutes)*time.Minute {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "step-up MFA assertion has expired; re-authenticate and retry"})
+			return
+		}
+	}
+
+	_, err = h.db.Exec(`
+		INSERT INTO authorization_approvals (id, authorization_id, policy_id, approver, decision, reason, mfa_assertion_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`, uuid.New(), authID, policy.ID, approver, req.Action, req.Reason, nullIfEmpty(req.MFAAssertionID))
+	if err != nil {
+		h.logger.Error("Failed to record approval decision", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to record approval decision"})
+		return
+	}
+
+	h.auditLogger.LogSecurityEvent(c.Request.Context(), approver, "authorization_decision_recorded", authID, "high", map[string]interface{}{
+		"action":    req.Action,
+		"policy_id": policy.ID,
+	})
+
+	if req.Action == "reject" {
+		if _, err := h.db.Exec(`
 			UPDATE authorized_targets
-			SET verification_status = $1, verified_by_user_id = $2, verified_at = NOW()
+			SET verification_status = 'rejected', verified_by_user_id = $1, verified_at = NOW(), rejection_reason = $2
 			WHERE id = $3
-		`
-		args = []interface{}{newStatus, userID, authID}
+		`, approver, req.Reason, authID); err != nil {
+			h.logger.Error("Failed to update authorization", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update authorization"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"id":                  authID,
+			"verification_status": "rejected",
+			"message":             "Authorization rejected",
+		})
+		return
 	}
 
-	_, err = h.db.Exec(updateQuery, args...)
+	quorumMet, firstApprovalAt, err := h.approvalQuorumMet(authID, policy)
+	if err != nil {
+		h.logger.Error("Failed to evaluate approval quorum", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to evaluate approval quorum"})
+		return
+	}
+
+	if !quorumMet {
+		c.JSON(http.StatusOK, gin.H{
+			"id":                  authID,
+			"verification_status": "pending",
+			"message":             fmt.Sprintf("Approval recorded; awaiting quorum of %d approver(s)", policy.RequiredApproverCount),
+		})
+		return
+	}
+
+	coolingOffRemaining := time.Duration(policy.CoolingOffMinutes)*time.Minute - time.Since(firstApprovalAt)
+	if coolingOffRemaining > 0 {
+		c.JSON(http.StatusOK, gin.H{
+			"id":                  authID,
+			"verification_status": "pending",
+			"message":             fmt.Sprintf("Quorum reached; cooling-off period active for %s more", coolingOffRemaining.Round(time.Second)),
+		})
+		return
+	}
+
+	var trustedSignatureCount int
+	err = h.db.Get(&trustedSignatureCount, `
+		SELECT COUNT(*) FROM authorization_signatures s
+		JOIN trusted_signers t ON t.organization_id = $1
+			AND t.fingerprint = s.signer_fingerprint
+			AND t.format = s.signature_format
+		WHERE s.authorization_id = $2
+	`, auth.OrganizationID, authID)
+	if err != nil {
+		h.logger.Error("Failed to check signature chain of trust", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to verify signature trust chain"})
+		return
+	}
+	if trustedSignatureCount == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "no signature on this authorization chains to a trusted signer"})
+		return
+	}
+
+	_, err = h.db.Exec(`
+		UPDATE authorized_targets
+		SET verification_status = 'approved', verified_by_user_id = $1, verified_at = NOW()
+		WHERE id = $2
+	`, approver, authID)
 	if err != nil {
 		h.logger.Error("Failed to update authorization", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update authorization"})
@@ -205,8 +870,278 @@ func (h *ScanAuthorizationHandler) VerifyAuthorization(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{
 		"id":                  authID,
-		"verification_status": newStatus,
-		"message":             fmt.Sprintf("Authorization %s", req.Action+"d"),
+		"verification_status": "approved",
+		"message":             "Authorization approved",
+	})
+}
+
+// ApprovalPolicy is a row in authorization_approval_policies: the quorum, role, and
+// step-up-MFA requirements for approving authorizations of a given classification
+// (or the org's default, when target_classification is NULL).
+type ApprovalPolicy struct {
+	ID                     string         `json:"id" db:"id"`
+	OrganizationID         string         `json:"organization_id" db:"organization_id"`
+	TargetClassification   *string        `json:"target_classification" db:"target_classification"`
+	RequiredApproverCount  int            `json:"required_approver_count" db:"required_approver_count"`
+	// RequiredRoles, when non-empty, restricts who can contribute to quorum to
+	// organization members holding one of these roles - e.g. a "prod" policy might
+	// require "security-lead". Empty means any org member may approve.
+	RequiredRoles          pq.StringArray `json:"required_roles" db:"required_roles"`
+	CoolingOffMinutes      int            `json:"cooling_off_minutes" db:"cooling_off_minutes"`
+	RequireStepUpMFA       bool           `json:"require_step_up_mfa" db:"require_step_up_mfa"`
+	StepUpMFAWindowMinutes int            `json:"step_up_mfa_window_minutes" db:"step_up_mfa_window_minutes"`
+}
+
+// getApprovalPolicy returns the most specific policy for (orgID, targetClassification),
+// falling back to the org's default (NULL classification) policy, and finally to a
+// single-approver, no-MFA policy for organizations that haven't configured one -
+// preserving today's any-single-approver behavior until an org opts into quorum.
+func (h *ScanAuthorizationHandler) getApprovalPolicy(orgID, targetClassification string) (*ApprovalPolicy, error) {
+	var policy ApprovalPolicy
+	err := h.db.Get(&policy, `
+		SELECT * FROM authorization_approval_policies
+		WHERE organization_id = $1 AND (target_classification = $2 OR target_classification IS NULL)
+		ORDER BY target_classification NULLS LAST
+		LIMIT 1
+	`, orgID, nullIfEmpty(targetClassification))
+	if err == sql.ErrNoRows {
+		return &ApprovalPolicy{OrganizationID: orgID, RequiredApproverCount: 1}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
+
+// approvalQuorumMet reports whether enough distinct approvers have approved (not
+// rejected) authID to satisfy policy's quorum, and the timestamp of the first such
+// approval (used to enforce the policy's cooling-off delay). When policy restricts
+// approval to RequiredRoles, approvers who no longer hold one of those roles (e.g.
+// a role change after they approved) don't count towards quorum.
+func (h *ScanAuthorizationHandler) approvalQuorumMet(authID string, policy *ApprovalPolicy) (bool, time.Time, error) {
+	var approvals []struct {
+		Approver  string    `db:"approver"`
+		CreatedAt time.Time `db:"created_at"`
+	}
+	err := h.db.Select(&approvals, `
+		SELECT approver, created_at FROM authorization_approvals
+		WHERE authorization_id = $1 AND decision = 'approve'
+		ORDER BY created_at ASC
+	`, authID)
+	if err != nil {
+		return false, time.Time{}, err
+	}
+
+	qualifying := map[string]time.Time{}
+	for _, a := range approvals {
+		if len(policy.RequiredRoles) > 0 {
+			role, err := h.memberRole(policy.OrganizationID, a.Approver)
+			if err != nil {
+				return false, time.Time{}, err
+			}
+			if !containsRole(policy.RequiredRoles, role) {
+				continue
+			}
+		}
+		if _, seen := qualifying[a.Approver]; !seen {
+			qualifying[a.Approver] = a.CreatedAt
+		}
+	}
+
+	var firstApproval time.Time
+	for _, at := range qualifying {
+		if firstApproval.IsZero() || at.Before(firstApproval) {
+			firstApproval = at
+		}
+	}
+
+	return len(qualifying) >= policy.RequiredApproverCount, firstApproval, nil
+}
+
+// memberRole returns userID's role within organizationID, or "" if they aren't a
+// member - used to enforce an approval policy's RequiredRoles.
+func (h *ScanAuthorizationHandler) memberRole(organizationID, userID string) (string, error) {
+	var role string
+	err := h.db.Get(&role, `
+		SELECT role FROM organization_members WHERE organization_id = $1 AND user_id = $2
+	`, organizationID, userID)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return role, nil
+}
+
+// containsRole reports whether role appears in required.
+func containsRole(required pq.StringArray, role string) bool {
+	for _, r := range required {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// activeDelegator returns the user ID that delegated its approval right to userID
+// for this authorization, if any such delegation is still unexpired - empty string
+// if userID is acting on its own behalf.
+func (h *ScanAuthorizationHandler) activeDelegator(authID, userID string) (string, error) {
+	var fromUserID string
+	err := h.db.Get(&fromUserID, `
+		SELECT from_user_id FROM authorization_approval_delegations
+		WHERE authorization_id = $1 AND to_user_id = $2 AND expires_at > NOW()
+		ORDER BY created_at DESC
+		LIMIT 1
+	`, authID, userID)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return fromUserID, nil
+}
+
+// DelegateApproval handles POST /api/v1/scan-authorizations/:id/delegate, letting an
+// approver temporarily hand their approval right on this authorization to another
+// user (e.g. while on leave) until expires_at. The authorization must belong to the
+// caller's own organization, and the caller must be a member of it (any role) -
+// otherwise an outsider could plant a delegation that reattributes someone else's
+// approval to themselves. The caller's role is not checked against an approval
+// policy's RequiredRoles here; VerifyAuthorization checks it when the delegated
+// approval is cast.
+func (h *ScanAuthorizationHandler) DelegateApproval(c *gin.Context) {
+	authID := c.Param("id")
+	fromUserID := c.GetString("user_id")
+	orgID := c.GetString("organization_id")
+	if orgID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Organization context required"})
+		return
+	}
+
+	var req struct {
+		ToUserID  string    `json:"to_user_id" binding:"required"`
+		ExpiresAt time.Time `json:"expires_at" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if !req.ExpiresAt.After(time.Now()) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "expires_at must be in the future"})
+		return
+	}
+
+	var auth Authorization
+	err := h.db.Get(&auth, "SELECT * FROM authorized_targets WHERE id = $1 AND organization_id = $2", authID, orgID)
+	if err == sql.ErrNoRows {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Authorization not found"})
+		return
+	}
+	if err != nil {
+		h.logger.Error("Failed to fetch authorization", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch authorization"})
+		return
+	}
+
+	role, err := h.memberRole(orgID, fromUserID)
+	if err != nil {
+		h.logger.Error("Failed to look up member role", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to look up member role"})
+		return
+	}
+	if role == "" {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Not eligible to delegate approval on this authorization"})
+		return
+	}
+
+	_, err = h.db.Exec(`
+		INSERT INTO authorization_approval_delegations (id, authorization_id, from_user_id, to_user_id, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`, uuid.New(), authID, fromUserID, req.ToUserID, req.ExpiresAt)
+	if err != nil {
+		h.logger.Error("Failed to record approval delegation", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to record approval delegation"})
+		return
+	}
+
+	h.auditLogger.LogSecurityEvent(c.Request.Context(), fromUserID, "approval_delegated", authID, "medium", map[string]interface{}{
+		"to_user_id": req.ToUserID,
+		"expires_at": req.ExpiresAt,
+	})
+
+	c.JSON(http.StatusCreated, gin.H{
+		"authorization_id": authID,
+		"from_user_id":     fromUserID,
+		"to_user_id":       req.ToUserID,
+		"expires_at":       req.ExpiresAt,
+	})
+}
+
+// GetApprovals handles GET /api/v1/scan-authorizations/:id/approvals and renders the
+// current approval tally against the quorum of the organization's default
+// (unclassified) approval policy; a classification-specific policy is not consulted.
+func (h *ScanAuthorizationHandler) GetApprovals(c *gin.Context) {
+	authID := c.Param("id")
+	orgID := c.GetString("organization_id")
+	if orgID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Organization context required"})
+		return
+	}
+
+	// Scoped to the caller's organization, same as CountersignAuthorization/
+	// UploadAuthorizationDocument - approver identities and mfa_assertion_id below
+	// are not another tenant's data to read.
+	var auth Authorization
+	err := h.db.Get(&auth, "SELECT * FROM authorized_targets WHERE id = $1 AND organization_id = $2", authID, orgID)
+	if err == sql.ErrNoRows {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Authorization not found"})
+		return
+	}
+	if err != nil {
+		h.logger.Error("Failed to fetch authorization", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch authorization"})
+		return
+	}
+
+	var approvals []struct {
+		ID             string    `json:"id" db:"id"`
+		Approver       string    `json:"approver" db:"approver"`
+		Decision       string    `json:"decision" db:"decision"`
+		PolicyID       string    `json:"policy_id" db:"policy_id"`
+		MFAAssertionID *string   `json:"mfa_assertion_id" db:"mfa_assertion_id"`
+		CreatedAt      time.Time `json:"created_at" db:"created_at"`
+	}
+	if err := h.db.Select(&approvals, `
+		SELECT * FROM authorization_approvals WHERE authorization_id = $1 ORDER BY created_at ASC
+	`, authID); err != nil {
+		h.logger.Error("Failed to load approvals", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load approvals"})
+		return
+	}
+
+	policy, err := h.getApprovalPolicy(auth.OrganizationID, "")
+	if err != nil {
+		h.logger.Error("Failed to load approval policy", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load approval policy"})
+		return
+	}
+
+	approveCount := 0
+	for _, a := range approvals {
+		if a.Decision == "approve" {
+			approveCount++
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"authorization_id":        authID,
+		"approvals":               approvals,
+		"approve_count":           approveCount,
+		"required_approver_count": policy.RequiredApproverCount,
 	})
 }
 
@@ -229,20 +1164,14 @@ func (h *ScanAuthorizationHandler) CheckTargetAuthorization(c *gin.Context) {
 		return
 	}
 
-	// Check if there's a valid, approved authorization
-	var authID string
-	err := h.db.Get(&authID, `
-		SELECT id FROM authorized_targets
-		WHERE organization_id = $1
-		AND target_type = $2
-		AND target_value = $3
-		AND verification_status = 'approved'
-		AND valid_from <= NOW()
-		AND valid_until >= NOW()
-		LIMIT 1
-	`, orgID, req.TargetType, req.TargetValue)
+	match, err := h.matchAuthorization(orgID, req.TargetType, req.TargetValue)
+	if err != nil {
+		h.logger.Error("Failed to check authorization", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check authorization"})
+		return
+	}
 
-	if err == sql.ErrNoRows {
+	if match == nil {
 		c.JSON(http.StatusOK, gin.H{
 			"authorized":       false,
 			"authorization_id": nil,
@@ -251,14 +1180,314 @@ func (h *ScanAuthorizationHandler) CheckTargetAuthorization(c *gin.Context) {
 		return
 	}
 
+	authID := match.AuthorizationID
+
+	var auth Authorization
+	if err := h.db.Get(&auth, "SELECT * FROM authorized_targets WHERE id = $1", authID); err != nil {
+		h.logger.Error("Failed to fetch matched authorization", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check authorization"})
+		return
+	}
+
+	scheduleOK, nextAllowedAt, err := h.scheduleStatus(auth, time.Now())
 	if err != nil {
-		h.logger.Error("Failed to check authorization", zap.Error(err))
+		h.logger.Error("Failed to evaluate authorization schedule", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check authorization"})
 		return
 	}
+	if !scheduleOK {
+		c.JSON(http.StatusOK, gin.H{
+			"authorized":       false,
+			"authorization_id": authID,
+			"next_allowed_at":  nextAllowedAt,
+			"message":          "Authorization exists but is outside its scheduled window or inside a blackout period",
+		})
+		return
+	}
+
+	var docs []AuthorizationDocumentRecord
+	if err := h.db.Select(&docs, `
+		SELECT * FROM authorization_documents WHERE authorization_id = $1
+	`, authID); err != nil {
+		h.logger.Error("Failed to load evidence documents", zap.Error(err))
+	}
+
+	for _, doc := range docs {
+		exists, err := h.vault.Exists(c.Request.Context(), doc.ObjectKey)
+		if err != nil {
+			h.logger.Error("Failed to verify evidence document exists", zap.Error(err), zap.String("object_key", doc.ObjectKey))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to verify evidence document"})
+			return
+		}
+		if !exists {
+			c.JSON(http.StatusOK, gin.H{
+				"authorized":       false,
+				"authorization_id": authID,
+				"message":          "Authorization's evidence document is missing from the vault",
+			})
+			return
+		}
+	}
+
+	var chain []AuthorizationSignature
+	if err := h.db.Select(&chain, `
+		SELECT * FROM authorization_signatures WHERE authorization_id = $1 ORDER BY created_at ASC
+	`, authID); err != nil {
+		h.logger.Error("Failed to load chain of custody", zap.Error(err))
+	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"authorized":       true,
 		"authorization_id": authID,
+		"match_reason":     match.Reason,
+		"chain_of_custody": chain,
 	})
 }
+
+// authorizationMatch records which approved authorization permitted a scan target,
+// and why - exact, cidr-contains, or subdomain-of - so the audit trail can show the
+// reasoning, not just a yes/no.
+type authorizationMatch struct {
+	AuthorizationID string
+	Reason          string
+}
+
+// currentWindow computes the cron-derived scan window containing ref, given an
+// anchor cron expression and a fixed window duration. It returns the window start
+// and end; ref may fall before, inside, or after the returned window.
+func currentWindow(sched cron.Schedule, windowDuration time.Duration, ref time.Time) (start, end time.Time) {
+	start = sched.Next(ref.Add(-windowDuration))
+	return start, start.Add(windowDuration)
+}
+
+// scheduleStatus reports whether auth currently authorizes a scan given its cron
+// window (if any) and blackout windows, and - if not - the next time it will.
+func (h *ScanAuthorizationHandler) scheduleStatus(auth Authorization, now time.Time) (authorized bool, nextAllowedAt *time.Time, err error) {
+	loc, err := time.LoadLocation(auth.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	nowInTZ := now.In(loc)
+
+	if auth.CronExpression != "" {
+		sched, parseErr := cronParser.Parse(auth.CronExpression)
+		if parseErr != nil {
+			return false, nil, fmt.Errorf("invalid stored cron_expression: %w", parseErr)
+		}
+		windowDuration, durErr := time.ParseDuration(auth.WindowDuration)
+		if durErr != nil {
+			return false, nil, fmt.Errorf("invalid stored window_duration: %w", durErr)
+		}
+
+		start, end := currentWindow(sched, windowDuration, nowInTZ)
+		if nowInTZ.Before(start) || !nowInTZ.Before(end) {
+			next := sched.Next(nowInTZ)
+			return false, &next, nil
+		}
+	}
+
+	var blackout TimeRange
+	err = h.db.Get(&blackout, `
+		SELECT * FROM authorization_blackout_windows
+		WHERE authorization_id = $1 AND starts_at <= $2 AND ends_at >= $2
+		LIMIT 1
+	`, auth.ID, now)
+	if err == nil {
+		return false, &blackout.EndsAt, nil
+	}
+	if err != sql.ErrNoRows {
+		return false, nil, err
+	}
+
+	return true, nil, nil
+}
+
+// upcomingWindows computes the next n cron-derived scan windows for auth, starting
+// from from. Returns nil (not an error) if auth has no cron schedule.
+func upcomingWindows(auth Authorization, n int, from time.Time) ([]TimeRange, error) {
+	if auth.CronExpression == "" {
+		return nil, nil
+	}
+
+	loc, err := time.LoadLocation(auth.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	sched, err := cronParser.Parse(auth.CronExpression)
+	if err != nil {
+		return nil, fmt.Errorf("invalid stored cron_expression: %w", err)
+	}
+	windowDuration, err := time.ParseDuration(auth.WindowDuration)
+	if err != nil {
+		return nil, fmt.Errorf("invalid stored window_duration: %w", err)
+	}
+
+	windows := make([]TimeRange, 0, n)
+	cursor := from.In(loc)
+	for i := 0; i < n; i++ {
+		start := sched.Next(cursor)
+		end := start.Add(windowDuration)
+		windows = append(windows, TimeRange{AuthorizationID: auth.ID, StartsAt: start, EndsAt: end})
+		cursor = start
+	}
+	return windows, nil
+}
+
+// maxScheduleWindows bounds the "n" query parameter on GetAuthorizationSchedule so a
+// negative value can't underflow upcomingWindows' slice capacity and a huge one can't
+// drive it into an unbounded loop of cron computations - both one-request DoSes.
+const maxScheduleWindows = 100
+
+// GetAuthorizationSchedule handles GET /api/v1/scan-authorizations/:id/schedule
+// and returns the next N computed cron windows so a caller (e.g. the scan
+// scheduler) can queue work rather than polling CheckTargetAuthorization.
+func (h *ScanAuthorizationHandler) GetAuthorizationSchedule(c *gin.Context) {
+	authID := c.Param("id")
+	orgID := c.GetString("organization_id")
+	if orgID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Organization context required"})
+		return
+	}
+
+	n := 5
+	if raw := c.Query("n"); raw != "" {
+		if parsed, err := fmt.Sscanf(raw, "%d", &n); err != nil || parsed != 1 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "n must be an integer"})
+			return
+		}
+		if n < 1 || n > maxScheduleWindows {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("n must be between 1 and %d", maxScheduleWindows)})
+			return
+		}
+	}
+
+	var auth Authorization
+	err := h.db.Get(&auth, "SELECT * FROM authorized_targets WHERE id = $1 AND organization_id = $2", authID, orgID)
+	if err == sql.ErrNoRows {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Authorization not found"})
+		return
+	}
+	if err != nil {
+		h.logger.Error("Failed to fetch authorization", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch authorization"})
+		return
+	}
+
+	windows, err := upcomingWindows(auth, n, time.Now())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"authorization_id": authID,
+		"windows":          windows,
+	})
+}
+
+// StartExpiryCheck runs until ctx is cancelled, periodically transitioning approved
+// authorizations whose valid_until has passed to 'expired' and emitting an audit
+// event - mirroring the authService.StartPulseCheck background-job pattern.
+func (h *ScanAuthorizationHandler) StartExpiryCheck(ctx context.Context) {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			h.expireOverdueAuthorizations(ctx)
+		}
+	}
+}
+
+func (h *ScanAuthorizationHandler) expireOverdueAuthorizations(ctx context.Context) {
+	var expired []string
+	err := h.db.SelectContext(ctx, &expired, `
+		UPDATE authorized_targets
+		SET verification_status = 'expired'
+		WHERE verification_status = 'approved' AND valid_until < NOW()
+		RETURNING id
+	`)
+	if err != nil {
+		h.logger.Error("Failed to expire overdue authorizations", zap.Error(err))
+		return
+	}
+
+	for _, authID := range expired {
+		h.auditLogger.LogSecurityEvent(ctx, "system", "authorization_expired", authID, "medium", map[string]interface{}{
+			"authorization_id": authID,
+		})
+	}
+}
+
+// matchAuthorization finds the most specific approved, time-valid authorization that
+// covers a scan target: an exact (target_type, target_value) match first, then CIDR
+// containment for IP targets, then apex/wildcard subdomain matching for domain
+// targets. CIDR containment is pushed into Postgres via the inet `<<=` operator so it
+// can use the GiST index on authorized_targets.ip_range instead of a table scan.
+func (h *ScanAuthorizationHandler) matchAuthorization(orgID, targetType, targetValue string) (*authorizationMatch, error) {
+	var exactID string
+	err := h.db.Get(&exactID, `
+		SELECT id FROM authorized_targets
+		WHERE organization_id = $1 AND target_type = $2 AND target_value = $3
+		AND verification_status = 'approved' AND valid_from <= NOW() AND valid_until >= NOW()
+		LIMIT 1
+	`, orgID, targetType, targetValue)
+	if err == nil {
+		return &authorizationMatch{AuthorizationID: exactID, Reason: "exact"}, nil
+	}
+	if err != sql.ErrNoRows {
+		return nil, err
+	}
+
+	if targetType == "ip" {
+		ip := targetValue
+		if host, _, splitErr := net.SplitHostPort(targetValue); splitErr == nil {
+			ip = host
+		}
+
+		var cidrID string
+		err := h.db.Get(&cidrID, `
+			SELECT id FROM authorized_targets
+			WHERE organization_id = $1 AND target_type = 'cidr' AND ip_range IS NOT NULL
+			AND $2::inet <<= ip_range
+			AND verification_status = 'approved' AND valid_from <= NOW() AND valid_until >= NOW()
+			ORDER BY masklen(ip_range) DESC
+			LIMIT 1
+		`, orgID, ip)
+		if err == nil {
+			return &authorizationMatch{AuthorizationID: cidrID, Reason: "cidr-contains"}, nil
+		}
+		if err != sql.ErrNoRows {
+			return nil, err
+		}
+	}
+
+	if targetType == "domain" {
+		var domainAuths []Authorization
+		err := h.db.Select(&domainAuths, `
+			SELECT * FROM authorized_targets
+			WHERE organization_id = $1 AND target_type = 'domain'
+			AND verification_status = 'approved' AND valid_from <= NOW() AND valid_until >= NOW()
+		`, orgID)
+		if err != nil {
+			return nil, err
+		}
+
+		// The longest matching domain is the most specific one.
+		bestID, bestLen := "", -1
+		for _, a := range domainAuths {
+			authDomain := strings.TrimPrefix(a.TargetValue, "*.")
+			if (targetValue == authDomain || strings.HasSuffix(targetValue, "."+authDomain)) && len(authDomain) > bestLen {
+				bestID, bestLen = a.ID, len(authDomain)
+			}
+		}
+		if bestID != "" {
+			return &authorizationMatch{AuthorizationID: bestID, Reason: "subdomain-of"}, nil
+		}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,113 @@
+// Package vault wraps an S3-compatible object store used as the evidence vault for
+// scan authorization documents (and, eventually, scan reports). Objects are addressed
+// by an immutable orgID/authID/sha256 key so a stored document can never be swapped
+// out from under an approved authorization.
+package vault
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"go.uber.org/zap"
+)
+
+// Config configures a Client. Endpoint should be left empty for AWS S3 itself and
+// set to a MinIO (or other S3-compatible) endpoint otherwise.
+type Config struct {
+	Bucket   string
+	Region   string
+	Endpoint string
+	KMSKeyID string // optional; enables SSE-KMS on Put when set
+}
+
+type Client struct {
+	s3       *s3.S3
+	uploader *s3manager.Uploader
+	bucket   string
+	kmsKeyID string
+	logger   *zap.Logger
+}
+
+func NewClient(cfg Config, logger *zap.Logger) (*Client, error) {
+	awsCfg := &aws.Config{Region: aws.String(cfg.Region)}
+	if cfg.Endpoint != "" {
+		awsCfg.Endpoint = aws.String(cfg.Endpoint)
+		awsCfg.S3ForcePathStyle = aws.Bool(true)
+	}
+
+	sess, err := session.NewSession(awsCfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create vault session: %w", err)
+	}
+
+	return &Client{
+		s3:       s3.New(sess),
+		uploader: s3manager.NewUploader(sess),
+		bucket:   cfg.Bucket,
+		kmsKeyID: cfg.KMSKeyID,
+		logger:   logger,
+	}, nil
+}
+
+// Key builds the immutable object key for a piece of evidence belonging to an
+// organization and authorization, addressed by the sha256 of its content.
+func Key(orgID, authID, sha256Hex string) string {
+	return fmt.Sprintf("%s/%s/%s", orgID, authID, sha256Hex)
+}
+
+func (c *Client) Put(ctx context.Context, key string, body []byte, contentType string) error {
+	input := &s3manager.UploadInput{
+		Bucket:      aws.String(c.bucket),
+		Key:         aws.String(key),
+		Body:        bytes.NewReader(body),
+		ContentType: aws.String(contentType),
+	}
+	if c.kmsKeyID != "" {
+		input.ServerSideEncryption = aws.String(s3.ServerSideEncryptionAwsKms)
+		input.SSEKMSKeyId = aws.String(c.kmsKeyID)
+	}
+
+	if _, err := c.uploader.UploadWithContext(ctx, input); err != nil {
+		return fmt.Errorf("failed to upload %s to evidence vault: %w", key, err)
+	}
+	return nil
+}
+
+// Exists reports whether key is present in the bucket via HEAD, without downloading it.
+func (c *Client) Exists(ctx context.Context, key string) (bool, error) {
+	_, err := c.s3.HeadObjectWithContext(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(c.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && (aerr.Code() == s3.ErrCodeNoSuchKey || aerr.Code() == "NotFound") {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// PresignGet returns a time-limited download URL for key.
+func (c *Client) PresignGet(key string, expiry time.Duration) (string, error) {
+	req, _ := c.s3.GetObjectRequest(&s3.GetObjectInput{
+		Bucket: aws.String(c.bucket),
+		Key:    aws.String(key),
+	})
+	url, err := req.Presign(expiry)
+	if err != nil {
+		return "", fmt.Errorf("failed to presign %s: %w", key, err)
+	}
+	return url, nil
+}
+
+func (c *Client) Bucket() string {
+	return c.bucket
+}